@@ -0,0 +1,290 @@
+package browser
+
+import (
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// candidateTags are the block-level elements scored when looking for a
+// page's main content.
+var candidateTags = []string{"p", "div", "article", "section"}
+
+// tagBaseWeight is the score an element of a given tag starts with, before
+// its text-density bonus is added.
+var tagBaseWeight = map[string]float64{
+	"article": 15,
+	"section": 10,
+	"div":     5,
+	"p":       5,
+}
+
+// Article is the result of running readability-style content extraction
+// over the current page with Browser.Article.
+type Article struct {
+	// Title is the article's title.
+	Title string
+
+	// Byline is the article's author, when one could be found.
+	Byline string
+
+	// PublishDate is the article's publish date, as found in the page's
+	// metadata. Empty when no publish date could be found.
+	PublishDate string
+
+	// TopImage is the URL of the article's leading image, when one exists.
+	TopImage string
+
+	// WordCount is the number of words in the extracted text.
+	WordCount int
+
+	// ReadingTime is the estimated time, in minutes, an average reader would
+	// take to read the article.
+	ReadingTime int
+
+	html string
+	text string
+}
+
+// HTML returns the cleaned HTML of the article's main content.
+func (a *Article) HTML() string {
+	return a.html
+}
+
+// Text returns the article's main content as plain text.
+func (a *Article) Text() string {
+	return a.text
+}
+
+// Download writes the article's HTML to the given writer.
+func (a *Article) Download(out io.Writer) (int64, error) {
+	n, err := io.WriteString(out, a.html)
+	return int64(n), err
+}
+
+// Article runs a readability-style algorithm over the current page and
+// returns its main content.
+func (bow *Browser) Article() (*Article, error) {
+	dom := bow.Dom()
+
+	best, _ := findBestCandidate(dom)
+	if best == nil {
+		best = dom.Find("body")
+	}
+
+	cleaned := cleanCandidate(best)
+	html, err := goquery.OuterHtml(cleaned)
+	if err != nil {
+		html, err = cleaned.Html()
+		if err != nil {
+			return nil, err
+		}
+	}
+	text := strings.TrimSpace(cleaned.Text())
+	words := strings.Fields(text)
+
+	return &Article{
+		Title:       articleTitle(dom),
+		Byline:      articleByline(dom),
+		PublishDate: articlePublishDate(dom),
+		TopImage:    articleTopImage(bow, cleaned),
+		WordCount:   len(words),
+		ReadingTime: readingTimeMinutes(len(words)),
+		html:        html,
+		text:        text,
+	}, nil
+}
+
+// findBestCandidate scores every candidate block in the page and returns the
+// highest-scoring one.
+//
+// Scores are accumulated keyed by the underlying *html.Node rather than by
+// *goquery.Selection: Selection.Parent() allocates a fresh Selection on
+// every call, so two Selections wrapping the same node would otherwise
+// receive separate, non-cumulative map entries and the half/quarter
+// ancestor propagation below would never actually combine sibling scores.
+func findBestCandidate(dom *goquery.Selection) (*goquery.Selection, float64) {
+	scores := map[*html.Node]float64{}
+	reps := map[*html.Node]*goquery.Selection{}
+	var candidates []*html.Node
+
+	record := func(s *goquery.Selection, score float64) {
+		node := s.Get(0)
+		if _, ok := reps[node]; !ok {
+			reps[node] = s
+		}
+		scores[node] += score
+	}
+
+	for _, tag := range candidateTags {
+		dom.Find(tag).Each(func(_ int, s *goquery.Selection) {
+			score := candidateScore(s)
+			if score <= 0 {
+				return
+			}
+			candidates = append(candidates, s.Get(0))
+			record(s, score)
+
+			if parent := s.Parent(); parent.Length() > 0 {
+				record(parent, score/2)
+				if grandparent := parent.Parent(); grandparent.Length() > 0 {
+					record(grandparent, score/4)
+				}
+			}
+		})
+	}
+
+	var best *html.Node
+	var bestScore float64
+	for _, n := range candidates {
+		if s := scores[n]; s > bestScore {
+			best = n
+			bestScore = s
+		}
+	}
+	if best == nil {
+		return nil, 0
+	}
+	return reps[best], bestScore
+}
+
+// candidateScore computes a block's base readability score, before scores
+// are propagated to its parent and grandparent.
+func candidateScore(s *goquery.Selection) float64 {
+	tag := goquery.NodeName(s)
+	text := strings.TrimSpace(s.Text())
+	if text == "" {
+		return 0
+	}
+
+	score := tagBaseWeight[tag]
+	score += float64(strings.Count(text, ","))
+
+	bonus := float64(len(text)) / 100
+	if bonus > 3 {
+		bonus = 3
+	}
+	score += bonus
+
+	return score
+}
+
+// cleanCandidate returns a clone of s with navigation/boilerplate elements
+// removed, and every direct child whose link density exceeds 0.5 removed.
+// Children are not scored before removal, and siblings of s are not
+// examined.
+func cleanCandidate(s *goquery.Selection) *goquery.Selection {
+	clone := s.Clone()
+	clone.Find("nav, aside, form, script, style, iframe").Remove()
+
+	clone.Children().Each(func(_ int, child *goquery.Selection) {
+		text := child.Text()
+		if len(text) == 0 {
+			return
+		}
+		if linkDensity(child) > 0.5 {
+			child.Remove()
+		}
+	})
+
+	return clone
+}
+
+// linkDensity is the ratio of the length of linked text to the length of
+// all text within s.
+func linkDensity(s *goquery.Selection) float64 {
+	text := strings.TrimSpace(s.Text())
+	if len(text) == 0 {
+		return 0
+	}
+
+	var linkLen int
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkLen += len(strings.TrimSpace(a.Text()))
+	})
+
+	return float64(linkLen) / float64(len(text))
+}
+
+// articleTitle picks a title, preferring the og:title meta tag, then the
+// first <h1>, then <title> with any " - Site Name" / " | Site Name" suffix
+// trimmed.
+func articleTitle(dom *goquery.Selection) string {
+	if content, ok := dom.Find(`meta[property="og:title"]`).Attr("content"); ok {
+		if t := strings.TrimSpace(content); t != "" {
+			return t
+		}
+	}
+	if h1 := strings.TrimSpace(dom.Find("h1").First().Text()); h1 != "" {
+		return h1
+	}
+
+	title := strings.TrimSpace(dom.Find("title").First().Text())
+	for _, sep := range []string{" | ", " - ", " — "} {
+		if i := strings.LastIndex(title, sep); i > 0 {
+			return strings.TrimSpace(title[:i])
+		}
+	}
+	return title
+}
+
+// articleByline looks for a common byline meta tag.
+func articleByline(dom *goquery.Selection) string {
+	for _, sel := range []string{`meta[name="author"]`, `meta[property="article:author"]`} {
+		if content, ok := dom.Find(sel).Attr("content"); ok {
+			if b := strings.TrimSpace(content); b != "" {
+				return b
+			}
+		}
+	}
+	return ""
+}
+
+// articlePublishDate looks for a common publish-date meta tag.
+func articlePublishDate(dom *goquery.Selection) string {
+	for _, sel := range []string{`meta[property="article:published_time"]`, `meta[name="date"]`} {
+		if content, ok := dom.Find(sel).Attr("content"); ok {
+			if d := strings.TrimSpace(content); d != "" {
+				return d
+			}
+		}
+	}
+	return ""
+}
+
+// articleTopImage looks for the og:image meta tag, falling back to the
+// first image inside the extracted content.
+func articleTopImage(bow *Browser, content *goquery.Selection) string {
+	if src, ok := bow.Dom().Find(`meta[property="og:image"]`).Attr("content"); ok {
+		if u, err := bow.ResolveStringUrl(src); err == nil {
+			return u
+		}
+	}
+	if src, ok := content.Find("img").First().Attr("src"); ok {
+		if u, err := bow.ResolveStringUrl(src); err == nil {
+			return u
+		}
+	}
+	return ""
+}
+
+// averageWordsPerMinute is used to estimate ReadingTime.
+const averageWordsPerMinute = 200
+
+// readingTimeMinutes estimates reading time, rounding up to at least 1
+// minute for any non-empty article.
+func readingTimeMinutes(words int) int {
+	if words == 0 {
+		return 0
+	}
+	minutes := words / averageWordsPerMinute
+	if words%averageWordsPerMinute != 0 {
+		minutes++
+	}
+	if minutes == 0 {
+		minutes = 1
+	}
+	return minutes
+}
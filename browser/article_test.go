@@ -0,0 +1,67 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// TestFindBestCandidateAccumulatesSiblingScores exercises the ancestor
+// propagation described in findBestCandidate's doc comment: two sibling <p>
+// tags under the same <div> should combine their half-scores on that div,
+// letting it outscore a single, higher-scoring decoy element elsewhere on
+// the page.
+func TestFindBestCandidateAccumulatesSiblingScores(t *testing.T) {
+	const page = `<html><body>
+<article id="decoy">Lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua ut enim ad minim veniam quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</article>
+<div id="main">
+<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur, excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.</p>
+<p>Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium, totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo.</p>
+</div>
+</body></html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("NewDocumentFromReader: %v", err)
+	}
+
+	best, score := findBestCandidate(doc.Selection)
+	if best == nil {
+		t.Fatal("expected a winning candidate, got nil")
+	}
+
+	if id, _ := best.Attr("id"); id != "main" {
+		t.Fatalf("expected the paragraphs' shared parent div#main to win, got %q (score %v)", id, score)
+	}
+
+	decoyScore := candidateScore(doc.Find("#decoy"))
+	if score <= decoyScore {
+		t.Fatalf("expected the propagated score (%v) to exceed the decoy's standalone score (%v)", score, decoyScore)
+	}
+}
+
+func TestFindBestCandidateIgnoresEmptyPage(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body></body></html>`))
+	if err != nil {
+		t.Fatalf("NewDocumentFromReader: %v", err)
+	}
+
+	best, score := findBestCandidate(doc.Selection)
+	if best != nil {
+		t.Fatalf("expected no candidate on an empty page, got %v (score %v)", best, score)
+	}
+}
+
+func TestLinkDensity(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(
+		`<div id="d">one two three <a href="/x">four five six seven eight nine</a></div>`))
+	if err != nil {
+		t.Fatalf("NewDocumentFromReader: %v", err)
+	}
+
+	got := linkDensity(doc.Find("#d"))
+	if got <= 0 || got >= 1 {
+		t.Fatalf("expected a link density strictly between 0 and 1, got %v", got)
+	}
+}
@@ -28,6 +28,10 @@ const (
 
 	// FollowRedirectsAttribute instructs a Browser to follow Location headers.
 	FollowRedirects
+
+	// RespectRobotsTxt instructs a Browser to honor the target host's
+	// robots.txt policy, refusing disallowed requests.
+	RespectRobotsTxt
 )
 
 var (
@@ -42,6 +46,9 @@ var (
 
 	// DefaultFollowRedirectsAttribute is the global value for the AttributeFollowRedirects attribute.
 	DefaultFollowRedirects = true
+
+	// DefaultRespectRobotsTxt is the global value for the RespectRobotsTxt attribute.
+	DefaultRespectRobotsTxt = false
 )
 
 // Downloadable represents an element that may be downloaded.
@@ -76,6 +83,58 @@ type Browsable interface {
 	// SetHeaders sets the headers the browser sends with each request.
 	SetHeaders(h http.Header)
 
+	// SetTransport sets the http.RoundTripper used to make requests, allowing
+	// callers to configure TLS settings, proxies, or request tracing.
+	SetTransport(rt http.RoundTripper)
+
+	// SetTimeout sets the timeout applied to each request made by the browser.
+	SetTimeout(d time.Duration)
+
+	// SetHTTPClient sets the *http.Client used to make requests. The client's
+	// CheckRedirect and Jar fields are overwritten to preserve the browser's
+	// redirect and cookie handling, and its Transport is wrapped so
+	// gemini:// and gopher:// requests still reach the browser's Gemini and
+	// Gopher handlers; the client's own Transport is kept for http/https.
+	SetHTTPClient(client *http.Client)
+
+	// SetRateLimit sets the minimum delay enforced between consecutive
+	// requests made to the same host. A zero duration disables rate limiting.
+	SetRateLimit(perHost time.Duration)
+
+	// SetRobotsFetcher overrides the mechanism used to fetch robots.txt,
+	// allowing tests to inject a fake policy.
+	SetRobotsFetcher(f RobotsFetcher)
+
+	// SetGeminiKnownHosts overrides the trust-on-first-use store used to
+	// verify Gemini server certificates.
+	SetGeminiKnownHosts(store GeminiKnownHosts)
+
+	// SetGeminiCerts sets the jar used to look up a client certificate to
+	// present for a given Gemini host.
+	SetGeminiCerts(jar GeminiCertJar)
+
+	// Use appends mw to the middleware chain run around every request.
+	Use(mw Middleware)
+
+	// OnRequest registers a callback fired immediately before a request is
+	// sent.
+	OnRequest(fn func(req *http.Request))
+
+	// OnResponse registers a callback fired after a response is received
+	// successfully.
+	OnResponse(fn func(req *http.Request, resp *http.Response))
+
+	// OnError registers a callback fired whenever sending a request fails.
+	OnError(fn func(req *http.Request, err error))
+
+	// OnRedirect registers a callback consulted whenever the browser is
+	// about to follow a redirect. Returning an error aborts the redirect.
+	OnRedirect(fn func(req *http.Request, via []*http.Request) error)
+
+	// OnHTML registers a callback fired once per loaded page with the
+	// selection of all elements matching selector.
+	OnHTML(selector string, fn func(*goquery.Selection))
+
 	// Open requests the given URL using the GET method.
 	Open(url string) error
 
@@ -115,6 +174,9 @@ type Browsable interface {
 	// Images returns an array of every image found in the page.
 	Images() []*Image
 
+	// Scripts returns an array of every script found in the page.
+	Scripts() []*Script
+
 	// SiteCookies returns the cookies for the current site.
 	SiteCookies() []*http.Cookie
 
@@ -147,6 +209,10 @@ type Browsable interface {
 
 	// Find returns the dom selections matching the given expression.
 	Find(expr string) *goquery.Selection
+
+	// Article runs a readability-style algorithm over the current page and
+	// returns its main content.
+	Article() (*Article, error)
 }
 
 // Default is the default Browser implementation.
@@ -174,6 +240,66 @@ type Browser struct {
 
 	// refresh is a timer used to meta refresh pages.
 	refresh *time.Timer
+
+	// transport is the http.RoundTripper used by the browser's http.Client.
+	// A nil value falls back to http.DefaultTransport.
+	transport http.RoundTripper
+
+	// timeout is applied to every request the browser makes. Zero means no timeout.
+	timeout time.Duration
+
+	// httpClient is an explicitly injected http.Client. When set, it's used
+	// instead of one built from transport/timeout.
+	httpClient *http.Client
+
+	// robots caches the robots.txt policy fetched for each host.
+	robots robotsCache
+
+	// robotsFetcher fetches and parses robots.txt. A nil value uses
+	// httpRobotsFetcher.
+	robotsFetcher RobotsFetcher
+
+	// rateLimit enforces a minimum delay between requests to the same host,
+	// when configured with SetRateLimit.
+	rateLimit *hostLimiter
+
+	// geminiKnownHosts verifies Gemini server certificates on a
+	// trust-on-first-use basis. A nil value uses a fresh
+	// *MemoryGeminiKnownHosts the first time it's needed.
+	geminiKnownHosts GeminiKnownHosts
+
+	// geminiCerts looks up the client certificate to present for a given
+	// Gemini host. A nil value means no client certificate is presented.
+	geminiCerts GeminiCertJar
+
+	// middleware wraps every request/response pair, registered with Use().
+	middleware []Middleware
+
+	// onRequest are the callbacks registered with OnRequest.
+	onRequest []func(req *http.Request)
+
+	// onResponse are the callbacks registered with OnResponse.
+	onResponse []func(req *http.Request, resp *http.Response)
+
+	// onError are the callbacks registered with OnError.
+	onError []func(req *http.Request, err error)
+
+	// onRedirect are the callbacks registered with OnRedirect.
+	onRedirect []func(req *http.Request, via []*http.Request) error
+
+	// onHTML are the selector/callback pairs registered with OnHTML.
+	onHTML []htmlHandler
+
+	// defaultsInstalled tracks whether the browser's built-in middleware and
+	// OnHTML handlers (referer propagation, refresh-timer bookkeeping, and
+	// meta-refresh handling) have been registered yet. See
+	// ensureDefaultMiddleware.
+	defaultsInstalled bool
+
+	// pendingReferer is the Referer header value sendGet/sendPost want
+	// applied to the next request. It's consumed and cleared by
+	// refererMiddleware.
+	pendingReferer string
 }
 
 // Open requests the given URL using the GET method.
@@ -308,11 +434,16 @@ func (bow *Browser) Links() []*Link {
 		if ok {
 			href, err := bow.ResolveStringUrl(href)
 			if err == nil {
-				links = append(links, &Link{
-					ID:   id,
-					Href: href,
-					Text: s.Text(),
-				})
+				u, err := url.Parse(href)
+				if err == nil {
+					links = append(links, &Link{
+						bow:  bow,
+						ID:   id,
+						URL:  u,
+						Href: href,
+						Text: s.Text(),
+					})
+				}
 			}
 		}
 	})
@@ -333,12 +464,17 @@ func (bow *Browser) Images() []*Image {
 		if ok {
 			src, err := bow.ResolveStringUrl(src)
 			if err == nil {
-				images = append(images, &Image{
-					ID:    id,
-					Src:   src,
-					Alt:   alt,
-					Title: title,
-				})
+				u, err := url.Parse(src)
+				if err == nil {
+					images = append(images, &Image{
+						bow:   bow,
+						ID:    id,
+						URL:   u,
+						Src:   src,
+						Alt:   alt,
+						Title: title,
+					})
+				}
 			}
 		}
 	})
@@ -346,6 +482,58 @@ func (bow *Browser) Images() []*Image {
 	return images
 }
 
+// Scripts returns an array of every script found in the page.
+func (bow *Browser) Scripts() []*Script {
+	sel := bow.Dom().Find("script[src]")
+	scripts := make([]*Script, 0, sel.Length())
+
+	sel.Each(func(_ int, s *goquery.Selection) {
+		id, _ := s.Attr("id")
+		typ, _ := s.Attr("type")
+		src, ok := s.Attr("src")
+		if ok {
+			src, err := bow.ResolveStringUrl(src)
+			if err == nil {
+				u, err := url.Parse(src)
+				if err == nil {
+					scripts = append(scripts, &Script{
+						bow:  bow,
+						ID:   id,
+						Type: typ,
+						URL:  u,
+					})
+				}
+			}
+		}
+	})
+
+	return scripts
+}
+
+// download fetches the contents of u using the browser's cookie-jar-aware
+// HTTP client and writes them to out. It's used by Link, Image, and Script
+// to implement Downloadable.
+func (bow *Browser) download(u *url.URL, out io.Writer) (int64, error) {
+	req, err := bow.request("GET", u.String())
+	if err != nil {
+		return 0, err
+	}
+	if err := bow.checkRobots(req); err != nil {
+		bow.fireError(req, err)
+		return 0, err
+	}
+	bow.throttle(req)
+
+	resp, err := bow.chain(req, bow.doRequest)
+	if err != nil {
+		bow.fireError(req, err)
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return io.Copy(out, resp.Body)
+}
+
 // SiteCookies returns the cookies for the current site.
 func (bow *Browser) SiteCookies() []*http.Cookie {
 	return bow.cookies.Cookies(bow.Url())
@@ -454,7 +642,18 @@ func (bow *Browser) Find(expr string) *goquery.Selection {
 
 // client creates, configures, and returns a *http.Client type.
 func (bow *Browser) client() *http.Client {
-	client := &http.Client{}
+	var client *http.Client
+	if bow.httpClient != nil {
+		client = bow.httpClient
+		if _, ok := client.Transport.(*schemeRouter); !ok {
+			client.Transport = bow.routerFor(client.Transport)
+		}
+	} else {
+		client = &http.Client{
+			Transport: bow.router(),
+			Timeout:   bow.timeout,
+		}
+	}
 	client.Jar = bow.cookies
 	client.CheckRedirect = bow.shouldRedirect
 	return client
@@ -467,11 +666,31 @@ func (bow *Browser) request(method, url string) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header = bow.headers
+	req.Header = bow.headers.Clone()
 	req.Header["User-Agent"] = []string{bow.userAgent}
 	return req, nil
 }
 
+// SetTransport sets the http.RoundTripper used to make requests, allowing
+// callers to configure TLS settings, proxies, or request tracing.
+func (bow *Browser) SetTransport(rt http.RoundTripper) {
+	bow.transport = rt
+}
+
+// SetTimeout sets the timeout applied to each request made by the browser.
+func (bow *Browser) SetTimeout(d time.Duration) {
+	bow.timeout = d
+}
+
+// SetHTTPClient sets the *http.Client used to make requests. The client's
+// CheckRedirect and Jar fields are overwritten to preserve the browser's
+// redirect and cookie handling, and its Transport is wrapped so gemini://
+// and gopher:// requests still reach the browser's Gemini and Gopher
+// handlers; the client's own Transport is kept for http/https.
+func (bow *Browser) SetHTTPClient(client *http.Client) {
+	bow.httpClient = client
+}
+
 // sendGet makes an HTTP GET request for the given URL.
 // When via is not nil, and AttributeSendReferer is true, the Referer header will
 // be set to via's URL.
@@ -480,9 +699,7 @@ func (bow *Browser) sendGet(url string, via string) error {
 	if err != nil {
 		return err
 	}
-	if bow.attributes[SendReferer] && via != "" {
-		req.Header["Referer"] = []string{via}
-	}
+	bow.pendingReferer = via
 
 	return bow.send(req)
 }
@@ -501,22 +718,29 @@ func (bow *Browser) sendPost(url string, bodyType string, body io.Reader, via st
 	}
 	req.Body = rc
 	req.Header["Content-Type"] = []string{bodyType}
-	if bow.attributes[SendReferer] && via != "" {
-		req.Header["Referer"] = []string{via}
-	}
+	bow.pendingReferer = via
 
 	return bow.send(req)
 }
 
 // send uses the given *http.Request to make an HTTP request.
 func (bow *Browser) send(req *http.Request) error {
-	bow.preSend()
-	resp, err := bow.client().Do(req)
+	bow.ensureDefaultMiddleware()
+
+	if err := bow.checkRobots(req); err != nil {
+		bow.fireError(req, err)
+		return err
+	}
+	bow.throttle(req)
+
+	resp, err := bow.chain(req, bow.doRequest)
 	if err != nil {
+		bow.fireError(req, err)
 		return err
 	}
 	body, err := goquery.NewDocumentFromResponse(resp)
 	if err != nil {
+		bow.fireError(req, err)
 		return err
 	}
 	bow.history.Push(bow.state)
@@ -525,43 +749,29 @@ func (bow *Browser) send(req *http.Request) error {
 		Response: resp,
 		Dom:      body,
 	}
-	bow.postSend()
+	bow.fireHTML()
 
 	return nil
 }
 
-// preSend sets browser state before sending a request.
-func (bow *Browser) preSend() {
-	if bow.refresh != nil {
-		bow.refresh.Stop()
-	}
-}
-
-// postSend sets browser state after sending a request.
-func (bow *Browser) postSend() {
-	if bow.attributes[MetaRefreshHandling] {
-		sel := bow.Dom().Find("meta[http-equiv='refresh']")
-		if sel.Length() > 0 {
-			attr, ok := sel.Attr("content")
-			if ok {
-				dur, err := time.ParseDuration(attr + "s")
-				if err == nil {
-					bow.refresh = time.NewTimer(dur)
-					go func() {
-						<-bow.refresh.C
-						bow.Reload()
-					}()
-				}
-			}
-		}
+// doRequest is the innermost link of the middleware chain: it fires the
+// OnRequest hooks, sends req with the browser's *http.Client, and fires the
+// OnResponse hooks on success.
+func (bow *Browser) doRequest(req *http.Request) (*http.Response, error) {
+	bow.fireRequest(req)
+	resp, err := bow.client().Do(req)
+	if err != nil {
+		return nil, err
 	}
+	bow.fireResponse(req, resp)
+	return resp, nil
 }
 
 // shouldRedirect is used as the value to http.Client.CheckRedirect.
-func (bow *Browser) shouldRedirect(req *http.Request, _ []*http.Request) error {
-	if bow.attributes[FollowRedirects] {
-		return nil
+func (bow *Browser) shouldRedirect(req *http.Request, via []*http.Request) error {
+	if !bow.attributes[FollowRedirects] {
+		return errors.NewLocation(
+			"Redirects are disabled. Cannot follow '%s'.", req.URL.String())
 	}
-	return errors.NewLocation(
-		"Redirects are disabled. Cannot follow '%s'.", req.URL.String())
+	return bow.fireRedirect(req, via)
 }
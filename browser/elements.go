@@ -0,0 +1,75 @@
+package browser
+
+import (
+	"io"
+	"net/url"
+)
+
+// Link stores the properties of a page link.
+type Link struct {
+	// bow is the Browser that discovered the link, used to download it.
+	bow *Browser
+
+	// ID is the value of the id attribute if one exists.
+	ID string
+
+	// URL is the resolved URL for the href attribute.
+	URL *url.URL
+
+	// Href is the resolved URL for the href attribute, as a string.
+	Href string
+
+	// Text is the text appearing between the opening and closing anchor tag.
+	Text string
+}
+
+// Download writes the contents of the linked document to the given writer.
+func (l *Link) Download(out io.Writer) (int64, error) {
+	return l.bow.download(l.URL, out)
+}
+
+// Image stores the properties of an image.
+type Image struct {
+	// bow is the Browser that discovered the image, used to download it.
+	bow *Browser
+
+	// ID is the value of the id attribute if one exists.
+	ID string
+
+	// URL is the resolved URL for the src attribute.
+	URL *url.URL
+
+	// Src is the resolved URL for the src attribute, as a string.
+	Src string
+
+	// Alt describes the image.
+	Alt string
+
+	// Title is the title attribute of the image.
+	Title string
+}
+
+// Download writes the image's data to the given writer.
+func (i *Image) Download(out io.Writer) (int64, error) {
+	return i.bow.download(i.URL, out)
+}
+
+// Script stores the properties of a script tag.
+type Script struct {
+	// bow is the Browser that discovered the script, used to download it.
+	bow *Browser
+
+	// ID is the value of the id attribute if one exists.
+	ID string
+
+	// Type is the value of the type attribute, such as "text/javascript".
+	Type string
+
+	// URL is the resolved URL for the src attribute.
+	URL *url.URL
+}
+
+// Download writes the script's source to the given writer.
+func (s *Script) Download(out io.Writer) (int64, error) {
+	return s.bow.download(s.URL, out)
+}
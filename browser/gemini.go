@@ -0,0 +1,245 @@
+package browser
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/headzoo/surf/errors"
+)
+
+// defaultGeminiPort is used when a gemini:// URL doesn't specify one.
+const defaultGeminiPort = "1965"
+
+// GeminiKnownHosts implements trust-on-first-use (TOFU) verification of
+// Gemini server certificates. A pointer to a zero MemoryGeminiKnownHosts
+// satisfies this interface and is used when no other store is configured
+// with SetGeminiKnownHosts.
+type GeminiKnownHosts interface {
+	// Verify checks cert's fingerprint against the one recorded for host.
+	// The first time a host is seen, its fingerprint is recorded and nil is
+	// returned. On subsequent connections, a non-nil error is returned when
+	// the fingerprint has changed.
+	Verify(host string, cert *x509.Certificate) error
+}
+
+// MemoryGeminiKnownHosts is an in-memory, trust-on-first-use store of
+// Gemini server certificate fingerprints.
+type MemoryGeminiKnownHosts struct {
+	mu           sync.Mutex
+	fingerprints map[string][32]byte
+}
+
+// Verify satisfies GeminiKnownHosts.
+func (m *MemoryGeminiKnownHosts) Verify(host string, cert *x509.Certificate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fingerprints == nil {
+		m.fingerprints = make(map[string][32]byte)
+	}
+	fp := sha256.Sum256(cert.Raw)
+
+	known, ok := m.fingerprints[host]
+	if !ok {
+		m.fingerprints[host] = fp
+		return nil
+	}
+	if known != fp {
+		return errors.NewGeminiProtocol(
+			"Gemini certificate for '%s' does not match the known fingerprint.", host)
+	}
+	return nil
+}
+
+// SetGeminiKnownHosts overrides the trust-on-first-use store used to verify
+// Gemini server certificates.
+func (bow *Browser) SetGeminiKnownHosts(store GeminiKnownHosts) {
+	bow.geminiKnownHosts = store
+}
+
+// SetGeminiCerts sets the jar used to look up a client certificate to
+// present for a given Gemini host.
+func (bow *Browser) SetGeminiCerts(jar GeminiCertJar) {
+	bow.geminiCerts = jar
+}
+
+// GeminiCertJar stores client certificates to present when connecting to
+// Gemini hosts, analogous to http.CookieJar.
+type GeminiCertJar interface {
+	// Cert returns the client certificate to present for host, if any.
+	Cert(host string) (tls.Certificate, bool)
+}
+
+// geminiTransport implements transport for gemini:// URLs.
+type geminiTransport struct {
+	bow *Browser
+}
+
+// Do satisfies transport. It dials the Gemini host, issues the request, and
+// converts the text/gemini response into an HTML document so the rest of
+// Browser keeps working unmodified.
+func (t *geminiTransport) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if req.URL.Port() == "" {
+		host = net.JoinHostPort(req.URL.Hostname(), defaultGeminiPort)
+	}
+
+	conf := &tls.Config{InsecureSkipVerify: true}
+	if t.bow.geminiCerts != nil {
+		if cert, ok := t.bow.geminiCerts.Cert(req.URL.Hostname()); ok {
+			conf.Certificates = []tls.Certificate{cert}
+		}
+	}
+
+	conn, err := tls.Dial("tcp", host, conf)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if t.bow.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(t.bow.timeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.verify(req.URL.Hostname(), conn); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(conn, req.URL.String()+"\r\n"); err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	header, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	header = strings.TrimRight(header, "\r\n")
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 {
+		return nil, errors.NewGeminiProtocol("gemini: malformed header %q", header)
+	}
+	status, meta := parts[0], parts[1]
+
+	statusCode, err := strconv.Atoi(status)
+	if err != nil {
+		return nil, errors.NewGeminiProtocol("gemini: malformed status %q", status)
+	}
+
+	body, contentType := convertGeminiBody(r, statusCode, meta)
+	resp := &http.Response{
+		Request:    req,
+		StatusCode: geminiToHTTPStatus(statusCode),
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	return resp, nil
+}
+
+// verify performs TOFU verification of the connection's leaf certificate.
+func (t *geminiTransport) verify(host string, conn *tls.Conn) error {
+	store := t.bow.geminiKnownHosts
+	if store == nil {
+		store = &MemoryGeminiKnownHosts{}
+		t.bow.geminiKnownHosts = store
+	}
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return errors.NewGeminiProtocol("gemini: server presented no certificate")
+	}
+	return store.Verify(host, state.PeerCertificates[0])
+}
+
+// geminiToHTTPStatus maps a Gemini status code's category to a roughly
+// analogous HTTP status code, so Browser.StatusCode() returns something
+// meaningful.
+func geminiToHTTPStatus(status int) int {
+	switch status / 10 {
+	case 2:
+		return http.StatusOK
+	case 3:
+		return http.StatusFound
+	case 4, 5:
+		return http.StatusNotFound
+	case 6:
+		return http.StatusForbidden
+	default:
+		return http.StatusOK
+	}
+}
+
+// convertGeminiBody reads a successful (2x) text/gemini response and
+// converts it to HTML. Non-2x responses are wrapped as a <pre> block of
+// their meta line so Dom()/Find() still work. A 2x response of any other
+// content type is returned unchanged, with meta as its content type.
+func convertGeminiBody(r *bufio.Reader, status int, meta string) (string, string) {
+	if status/10 != 2 {
+		return "<html><body><pre>" + html.EscapeString(meta) + "</pre></body></html>", "text/html"
+	}
+	if !strings.HasPrefix(meta, "text/gemini") {
+		data, _ := io.ReadAll(r)
+		return string(data), meta
+	}
+
+	var out strings.Builder
+	out.WriteString("<html><body>")
+
+	inPre := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "```"):
+			if inPre {
+				out.WriteString("</pre>")
+			} else {
+				out.WriteString("<pre>")
+			}
+			inPre = !inPre
+		case inPre:
+			out.WriteString(html.EscapeString(line))
+			out.WriteString("\n")
+		case strings.HasPrefix(line, "=>"):
+			out.WriteString(geminiLinkToHTML(line))
+		case strings.HasPrefix(line, "###"):
+			out.WriteString("<h3>" + html.EscapeString(strings.TrimSpace(line[3:])) + "</h3>")
+		case strings.HasPrefix(line, "##"):
+			out.WriteString("<h2>" + html.EscapeString(strings.TrimSpace(line[2:])) + "</h2>")
+		case strings.HasPrefix(line, "#"):
+			out.WriteString("<h1>" + html.EscapeString(strings.TrimSpace(line[1:])) + "</h1>")
+		default:
+			out.WriteString("<p>" + html.EscapeString(line) + "</p>")
+		}
+	}
+	out.WriteString("</body></html>")
+
+	return out.String(), "text/html"
+}
+
+// geminiLinkToHTML converts a single "=> url text" line to an <a> tag.
+func geminiLinkToHTML(line string) string {
+	rest := strings.TrimSpace(line[2:])
+	fields := strings.SplitN(rest, " ", 2)
+
+	url := fields[0]
+	text := url
+	if len(fields) == 2 {
+		text = strings.TrimSpace(fields[1])
+	}
+
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+}
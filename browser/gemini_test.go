@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestConvertGeminiBodyGemtext(t *testing.T) {
+	const gemtext = "# Title\n" +
+		"## Section\n" +
+		"A plain line.\n" +
+		"=> gemini://example.com/foo Foo link\n" +
+		"```\n" +
+		"code <line>\n" +
+		"```\n"
+
+	body, contentType := convertGeminiBody(bufio.NewReader(strings.NewReader(gemtext)), 20, "text/gemini")
+
+	if contentType != "text/html" {
+		t.Fatalf("expected content type text/html, got %q", contentType)
+	}
+	for _, want := range []string{
+		"<h1>Title</h1>",
+		"<h2>Section</h2>",
+		"<p>A plain line.</p>",
+		`<a href="gemini://example.com/foo">Foo link</a>`,
+		"<pre>code &lt;line&gt;\n</pre>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestConvertGeminiBodyNonSuccessStatus(t *testing.T) {
+	body, contentType := convertGeminiBody(bufio.NewReader(strings.NewReader("")), 51, "not found")
+
+	if contentType != "text/html" {
+		t.Fatalf("expected content type text/html, got %q", contentType)
+	}
+	if !strings.Contains(body, "<pre>not found</pre>") {
+		t.Fatalf("expected the meta line to be rendered, got:\n%s", body)
+	}
+}
+
+func TestConvertGeminiBodyNonGemtextPassesThrough(t *testing.T) {
+	const raw = `{"not":"gemtext"}`
+	body, contentType := convertGeminiBody(bufio.NewReader(strings.NewReader(raw)), 20, "application/json")
+
+	if contentType != "application/json" {
+		t.Fatalf("expected the original content type to pass through, got %q", contentType)
+	}
+	if body != raw {
+		t.Fatalf("expected the body to pass through unmodified, got %q", body)
+	}
+}
+
+func TestGeminiToHTTPStatus(t *testing.T) {
+	cases := map[int]int{
+		20: 200,
+		30: 302,
+		44: 404,
+		51: 404,
+		61: 403,
+	}
+	for status, want := range cases {
+		if got := geminiToHTTPStatus(status); got != want {
+			t.Errorf("geminiToHTTPStatus(%d) = %d, want %d", status, got, want)
+		}
+	}
+}
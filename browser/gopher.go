@@ -0,0 +1,106 @@
+package browser
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultGopherPort is used when a gopher:// URL doesn't specify one.
+const defaultGopherPort = "70"
+
+// gopherTransport implements transport for gopher:// URLs.
+type gopherTransport struct {
+	bow *Browser
+}
+
+// Do satisfies transport. It dials the Gopher host, sends the selector, and
+// converts the response into an HTML document so the rest of Browser keeps
+// working unmodified.
+func (t *gopherTransport) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if req.URL.Port() == "" {
+		host = net.JoinHostPort(req.URL.Hostname(), defaultGopherPort)
+	}
+
+	conn, err := net.Dial("tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if t.bow.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(t.bow.timeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	selector := gopherSelector(req.URL.Path)
+	if _, err := io.WriteString(conn, selector+"\r\n"); err != nil {
+		return nil, err
+	}
+
+	body := convertGopherBody(bufio.NewReader(conn))
+	resp := &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	return resp, nil
+}
+
+// gopherSelector extracts the selector from a gopher:// URL's path. Per
+// RFC 1738, the path is the gophertype (a single character) immediately
+// followed by the selector, e.g. "/1/articles/foo" is type '1' with
+// selector "/articles/foo" — the gophertype itself isn't part of what gets
+// sent to the server.
+func gopherSelector(path string) string {
+	if len(path) >= 2 && path[0] == '/' {
+		return path[2:]
+	}
+	return path
+}
+
+// convertGopherBody reads a Gopher menu and converts its type-1 (submenu)
+// lines to HTML <a> elements. Lines of other item types are rendered as
+// plain text so the page still reads sensibly.
+func convertGopherBody(r *bufio.Reader) string {
+	var out strings.Builder
+	out.WriteString("<html><body>")
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "." {
+			break
+		}
+		if line == "" {
+			continue
+		}
+
+		itemType := line[0]
+		fields := strings.Split(line[1:], "\t")
+		display := fields[0]
+
+		if itemType != '1' || len(fields) < 4 {
+			out.WriteString("<p>" + html.EscapeString(display) + "</p>")
+			continue
+		}
+
+		selector, gHost, port := fields[1], fields[2], fields[3]
+		href := "gopher://" + net.JoinHostPort(gHost, port) + "/" + string(itemType) + selector
+		out.WriteString(gopherLinkToHTML(href, display))
+	}
+	out.WriteString("</body></html>")
+
+	return out.String()
+}
+
+// gopherLinkToHTML converts a single menu entry to an <a> tag.
+func gopherLinkToHTML(href, display string) string {
+	return `<a href="` + html.EscapeString(href) + `">` + html.EscapeString(display) + `</a>`
+}
@@ -0,0 +1,46 @@
+package browser
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestGopherSelector(t *testing.T) {
+	cases := map[string]string{
+		"/1/articles/foo": "/articles/foo",
+		"/articles/foo":   "rticles/foo",
+		"/1":              "",
+		"":                "",
+		"/":               "/",
+	}
+	for path, want := range cases {
+		if got := gopherSelector(path); got != want {
+			t.Errorf("gopherSelector(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestConvertGopherBody(t *testing.T) {
+	const menu = "1A submenu\t/sub\texample.com\t70\r\n" +
+		"iJust some info\tfake\texample.com\t70\r\n" +
+		".\r\n"
+
+	body := convertGopherBody(bufio.NewReader(strings.NewReader(menu)))
+
+	if !strings.Contains(body, `<a href="gopher://example.com:70/1/sub">A submenu</a>`) {
+		t.Errorf("expected a type-1 entry to become a link, got:\n%s", body)
+	}
+	if !strings.Contains(body, "<p>Just some info</p>") {
+		t.Errorf("expected a non-type-1 entry to be rendered as text, got:\n%s", body)
+	}
+}
+
+func TestConvertGopherBodyStopsAtDot(t *testing.T) {
+	const menu = "iHidden after the terminator\r\n"
+	body := convertGopherBody(bufio.NewReader(strings.NewReader(".\r\n" + menu)))
+
+	if strings.Contains(body, "Hidden") {
+		t.Fatalf("expected the menu to stop at the lone-dot terminator, got:\n%s", body)
+	}
+}
@@ -0,0 +1,180 @@
+package browser
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Middleware wraps a request, optionally short-circuiting or rewriting it
+// before and after the rest of the chain runs. Calling next sends req
+// through the remaining middleware and, eventually, the browser's
+// http.Client. Middleware is the extension point for logging, metrics,
+// retry with backoff, and response caching.
+type Middleware func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error)
+
+// htmlHandler pairs a DOM selector with the callback registered for it
+// with OnHTML.
+type htmlHandler struct {
+	selector string
+	handler  func(*goquery.Selection)
+}
+
+// Use appends mw to the middleware chain run around every request sent by
+// the browser. Middleware runs in registration order: the first one added
+// is the outermost wrapper and sees the request first.
+func (bow *Browser) Use(mw Middleware) {
+	bow.middleware = append(bow.middleware, mw)
+}
+
+// OnRequest registers a callback fired immediately before a request is
+// sent, after the middleware chain has had a chance to rewrite it.
+func (bow *Browser) OnRequest(fn func(req *http.Request)) {
+	bow.onRequest = append(bow.onRequest, fn)
+}
+
+// OnResponse registers a callback fired after a response is received
+// successfully, before the page is parsed into the browser's DOM.
+func (bow *Browser) OnResponse(fn func(req *http.Request, resp *http.Response)) {
+	bow.onResponse = append(bow.onResponse, fn)
+}
+
+// OnError registers a callback fired whenever sending a request fails,
+// whether due to robots.txt, the network, or parsing the response body.
+func (bow *Browser) OnError(fn func(req *http.Request, err error)) {
+	bow.onError = append(bow.onError, fn)
+}
+
+// OnRedirect registers a callback consulted whenever the browser is about
+// to follow a redirect. Returning an error aborts the redirect; a nil
+// return lets it proceed.
+func (bow *Browser) OnRedirect(fn func(req *http.Request, via []*http.Request) error) {
+	bow.onRedirect = append(bow.onRedirect, fn)
+}
+
+// OnHTML registers a callback fired once per loaded page with the selection
+// of all elements matching selector, after the page has been parsed into the
+// browser's DOM. It is not fired when nothing matches.
+func (bow *Browser) OnHTML(selector string, fn func(*goquery.Selection)) {
+	bow.onHTML = append(bow.onHTML, htmlHandler{selector: selector, handler: fn})
+}
+
+// chain runs req through the registered middleware, ending with send as
+// the innermost handler.
+func (bow *Browser) chain(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	h := send
+	for i := len(bow.middleware) - 1; i >= 0; i-- {
+		mw := bow.middleware[i]
+		next := h
+		h = func(r *http.Request) (*http.Response, error) {
+			return mw(r, next)
+		}
+	}
+	return h(req)
+}
+
+// fireRequest runs the registered OnRequest callbacks.
+func (bow *Browser) fireRequest(req *http.Request) {
+	for _, fn := range bow.onRequest {
+		fn(req)
+	}
+}
+
+// fireResponse runs the registered OnResponse callbacks.
+func (bow *Browser) fireResponse(req *http.Request, resp *http.Response) {
+	for _, fn := range bow.onResponse {
+		fn(req, resp)
+	}
+}
+
+// fireError runs the registered OnError callbacks.
+func (bow *Browser) fireError(req *http.Request, err error) {
+	for _, fn := range bow.onError {
+		fn(req, err)
+	}
+}
+
+// fireRedirect runs the registered OnRedirect callbacks, stopping at and
+// returning the first error.
+func (bow *Browser) fireRedirect(req *http.Request, via []*http.Request) error {
+	for _, fn := range bow.onRedirect {
+		if err := fn(req, via); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fireHTML runs the registered OnHTML callbacks against the current page.
+func (bow *Browser) fireHTML() {
+	for _, h := range bow.onHTML {
+		sel := bow.Find(h.selector)
+		if sel.Length() > 0 {
+			h.handler(sel)
+		}
+	}
+}
+
+// ensureDefaultMiddleware registers the browser's own request/referer/
+// meta-refresh behavior on the same Use()/OnHTML extension points available
+// to callers, the first time a request is sent. Because it's ordinary
+// middleware and an ordinary OnHTML handler rather than hardcoded calls in
+// send(), a caller can layer additional middleware around it, or disable the
+// pieces it controls with SetAttribute, instead of being stuck with
+// whatever send() does.
+func (bow *Browser) ensureDefaultMiddleware() {
+	if bow.defaultsInstalled {
+		return
+	}
+	bow.defaultsInstalled = true
+
+	bow.Use(bow.refererMiddleware)
+	bow.Use(bow.stopRefreshMiddleware)
+	bow.OnHTML("meta[http-equiv='refresh']", bow.handleMetaRefresh)
+}
+
+// refererMiddleware applies the Referer header queued by sendGet/sendPost,
+// when SendReferer is enabled.
+func (bow *Browser) refererMiddleware(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if bow.attributes[SendReferer] && bow.pendingReferer != "" {
+		req.Header.Set("Referer", bow.pendingReferer)
+	}
+	bow.pendingReferer = ""
+
+	return next(req)
+}
+
+// stopRefreshMiddleware cancels any pending meta-refresh timer from a
+// previous page before a new request goes out.
+func (bow *Browser) stopRefreshMiddleware(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if bow.refresh != nil {
+		bow.refresh.Stop()
+	}
+
+	return next(req)
+}
+
+// handleMetaRefresh is the built-in OnHTML callback that schedules a Reload
+// when the loaded page contains a refresh meta tag, and MetaRefreshHandling
+// is enabled.
+func (bow *Browser) handleMetaRefresh(sel *goquery.Selection) {
+	if !bow.attributes[MetaRefreshHandling] {
+		return
+	}
+
+	attr, ok := sel.Attr("content")
+	if !ok {
+		return
+	}
+	dur, err := time.ParseDuration(attr + "s")
+	if err != nil {
+		return
+	}
+
+	bow.refresh = time.NewTimer(dur)
+	go func() {
+		<-bow.refresh.C
+		bow.Reload()
+	}()
+}
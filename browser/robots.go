@@ -0,0 +1,240 @@
+package browser
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/headzoo/surf/errors"
+)
+
+// RobotsPolicy is the parsed robots.txt rules for a single host, scoped to
+// the user agent the rules were fetched with.
+type RobotsPolicy struct {
+	disallow []string
+}
+
+// Allowed reports whether the given path may be requested under this policy.
+func (p *RobotsPolicy) Allowed(path string) bool {
+	if p == nil {
+		return true
+	}
+	for _, prefix := range p.disallow {
+		if prefix == "" {
+			continue
+		}
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// RobotsFetcher fetches and parses the robots.txt policy for a host. Tests
+// can implement this interface to inject a fake policy via SetRobotsFetcher.
+type RobotsFetcher interface {
+	// Fetch retrieves and parses the robots.txt file for scheme://host,
+	// scoped to the rules that apply to userAgent.
+	Fetch(scheme, host, userAgent string) (*RobotsPolicy, error)
+}
+
+// httpRobotsFetcher fetches robots.txt over HTTP(S) using the browser's own
+// client, so proxies, TLS settings, and timeouts configured via SetTransport
+// and friends are honored.
+type httpRobotsFetcher struct {
+	bow *Browser
+}
+
+// Fetch retrieves and parses scheme://host/robots.txt.
+func (f *httpRobotsFetcher) Fetch(scheme, host, userAgent string) (*RobotsPolicy, error) {
+	req, err := http.NewRequest("GET", scheme+"://"+host+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := f.bow.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &RobotsPolicy{}, nil
+	}
+	return parseRobotsTxt(resp.Body, userAgent), nil
+}
+
+// robotsGroup is one User-agent block from a robots.txt file: the set of
+// agent names the block applies to (a block may be introduced by several
+// consecutive "User-agent:" lines sharing one set of rules), in the order
+// they were declared, and the Disallow rules that follow.
+type robotsGroup struct {
+	agents   []string
+	disallow []string
+}
+
+// parseRobotsTxt parses the Disallow rules that apply to userAgent, falling
+// back to the "*" group when there's no group specifically for it. Groups
+// are matched in the order robots.txt declares them, so the result is
+// deterministic even when more than one non-"*" group matches userAgent.
+func parseRobotsTxt(body io.Reader, userAgent string) *RobotsPolicy {
+	scanner := bufio.NewScanner(body)
+	var groups []*robotsGroup
+	lastWasUA := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(parts[0]))
+		value := strings.TrimSpace(parts[1])
+
+		switch field {
+		case "user-agent":
+			agent := strings.ToLower(value)
+			if !lastWasUA || len(groups) == 0 {
+				groups = append(groups, &robotsGroup{})
+			}
+			g := groups[len(groups)-1]
+			g.agents = append(g.agents, agent)
+			lastWasUA = true
+		case "disallow":
+			if len(groups) == 0 {
+				continue
+			}
+			g := groups[len(groups)-1]
+			g.disallow = append(g.disallow, value)
+			lastWasUA = false
+		default:
+			lastWasUA = false
+		}
+	}
+
+	ua := strings.ToLower(userAgent)
+	var wildcard []string
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			if agent == "*" {
+				wildcard = append(wildcard, g.disallow...)
+				continue
+			}
+			if strings.Contains(ua, agent) {
+				return &RobotsPolicy{disallow: g.disallow}
+			}
+		}
+	}
+	return &RobotsPolicy{disallow: wildcard}
+}
+
+// robotsCache caches RobotsPolicy values per host so send() doesn't refetch
+// robots.txt on every request.
+type robotsCache struct {
+	mu       sync.Mutex
+	policies map[string]*RobotsPolicy
+}
+
+func (c *robotsCache) get(host string) (*RobotsPolicy, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.policies[host]
+	return p, ok
+}
+
+func (c *robotsCache) set(host string, p *RobotsPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.policies == nil {
+		c.policies = make(map[string]*RobotsPolicy)
+	}
+	c.policies[host] = p
+}
+
+// hostLimiter enforces a minimum delay between consecutive requests to the
+// same host.
+type hostLimiter struct {
+	mu       sync.Mutex
+	perHost  time.Duration
+	lastSeen map[string]time.Time
+}
+
+// wait blocks until it's been at least perHost since the last request to
+// host, then records the current request.
+func (l *hostLimiter) wait(host string) {
+	l.mu.Lock()
+	if l.lastSeen == nil {
+		l.lastSeen = make(map[string]time.Time)
+	}
+	last, ok := l.lastSeen[host]
+	now := time.Now()
+	var sleep time.Duration
+	if ok {
+		if elapsed := now.Sub(last); elapsed < l.perHost {
+			sleep = l.perHost - elapsed
+		}
+	}
+	l.lastSeen[host] = now.Add(sleep)
+	l.mu.Unlock()
+
+	if sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
+
+// SetRateLimit sets the minimum delay enforced between consecutive requests
+// made to the same host. A zero duration disables rate limiting.
+func (bow *Browser) SetRateLimit(perHost time.Duration) {
+	bow.rateLimit = &hostLimiter{perHost: perHost}
+}
+
+// SetRobotsFetcher overrides the mechanism used to fetch robots.txt,
+// allowing tests to inject a fake policy.
+func (bow *Browser) SetRobotsFetcher(f RobotsFetcher) {
+	bow.robotsFetcher = f
+}
+
+// checkRobots returns errors.NewRobotsDenied when RespectRobotsTxt is enabled
+// and req's URL is disallowed by the target host's robots.txt.
+func (bow *Browser) checkRobots(req *http.Request) error {
+	if !bow.attributes[RespectRobotsTxt] {
+		return nil
+	}
+
+	host := req.URL.Host
+	policy, ok := bow.robots.get(host)
+	if !ok {
+		fetcher := bow.robotsFetcher
+		if fetcher == nil {
+			fetcher = &httpRobotsFetcher{bow: bow}
+		}
+		fetched, err := fetcher.Fetch(req.URL.Scheme, host, bow.userAgent)
+		if err != nil {
+			// A fetch failure is treated as "no restrictions" rather than
+			// blocking the browser entirely.
+			fetched = &RobotsPolicy{}
+		}
+		policy = fetched
+		bow.robots.set(host, policy)
+	}
+
+	if !policy.Allowed(req.URL.Path) {
+		return errors.NewRobotsDenied(
+			"robots.txt disallows '%s' for host '%s'.", req.URL.Path, host)
+	}
+	return nil
+}
+
+// throttle blocks until it's safe to send another request to req's host,
+// when a rate limit has been configured with SetRateLimit.
+func (bow *Browser) throttle(req *http.Request) {
+	if bow.rateLimit != nil {
+		bow.rateLimit.wait(req.URL.Host)
+	}
+}
@@ -0,0 +1,79 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRobotsTxtWildcardFallback(t *testing.T) {
+	body := strings.NewReader(`
+User-agent: *
+Disallow: /private
+Disallow: /tmp
+`)
+	policy := parseRobotsTxt(body, "surf/1.0")
+
+	if policy.Allowed("/private/x") {
+		t.Error("expected /private/x to be disallowed")
+	}
+	if policy.Allowed("/tmp/x") {
+		t.Error("expected /tmp/x to be disallowed")
+	}
+	if !policy.Allowed("/public") {
+		t.Error("expected /public to be allowed")
+	}
+}
+
+func TestParseRobotsTxtSpecificAgentOverridesWildcard(t *testing.T) {
+	body := strings.NewReader(`
+User-agent: *
+Disallow: /
+
+User-agent: googlebot
+Disallow: /private
+`)
+	policy := parseRobotsTxt(body, "Googlebot/2.1")
+
+	if !policy.Allowed("/public") {
+		t.Error("expected the googlebot group, not the wildcard group, to apply")
+	}
+	if policy.Allowed("/private") {
+		t.Error("expected /private to be disallowed for googlebot")
+	}
+}
+
+func TestParseRobotsTxtMultipleUserAgentLinesShareRules(t *testing.T) {
+	raw := `
+User-agent: agenta
+User-agent: agentb
+Disallow: /shared
+
+User-agent: *
+Disallow: /other
+`
+	for _, ua := range []string{"agenta", "agentb"} {
+		policy := parseRobotsTxt(strings.NewReader(raw), ua)
+		if policy.Allowed("/shared") {
+			t.Errorf("agent %q: expected /shared to be disallowed", ua)
+		}
+		if !policy.Allowed("/other") {
+			t.Errorf("agent %q: expected /other (wildcard-only) to be allowed", ua)
+		}
+	}
+}
+
+func TestParseRobotsTxtIsDeterministicAcrossMultipleNonWildcardGroups(t *testing.T) {
+	raw := `
+User-agent: agentx
+Disallow: /x
+
+User-agent: agenty
+Disallow: /y
+`
+	for i := 0; i < 20; i++ {
+		policy := parseRobotsTxt(strings.NewReader(raw), "agentx agenty")
+		if policy.Allowed("/x") != false || policy.Allowed("/y") != true {
+			t.Fatalf("run %d: expected the first matching group (agentx) to win consistently, got disallow=%v", i, policy.disallow)
+		}
+	}
+}
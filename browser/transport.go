@@ -0,0 +1,84 @@
+package browser
+
+import "net/http"
+
+// transport is implemented by a per-scheme request handler. The existing
+// net/http path is the default transport; Gemini and Gopher register their
+// own so Browser can Open/Click/Download gemini:// and gopher:// URLs using
+// the same *http.Request/*http.Response shape as everything else.
+type transport interface {
+	// Do sends req and returns the resulting response.
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// httpTransport is the default transport. It delegates to an underlying
+// http.RoundTripper, which is http.DefaultTransport unless the browser was
+// given one with SetTransport, or as the Transport of a client given with
+// SetHTTPClient.
+type httpTransport struct {
+	rt http.RoundTripper
+}
+
+// Do satisfies transport.
+func (t *httpTransport) Do(req *http.Request) (*http.Response, error) {
+	rt := t.rt
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return rt.RoundTrip(req)
+}
+
+// schemeRouter dispatches a request to the transport registered for its
+// URL scheme, falling back to httpTransport for anything unregistered.
+type schemeRouter struct {
+	handlers map[string]transport
+}
+
+// newSchemeRouter creates a *schemeRouter with the default http/https
+// handlers registered, wrapping rt (which may be nil).
+func newSchemeRouter(rt http.RoundTripper) *schemeRouter {
+	h := &httpTransport{rt: rt}
+	return &schemeRouter{
+		handlers: map[string]transport{
+			"http":  h,
+			"https": h,
+		},
+	}
+}
+
+// register adds or replaces the transport used for the given URL scheme.
+func (r *schemeRouter) register(scheme string, t transport) {
+	r.handlers[scheme] = t
+}
+
+// Do satisfies transport.
+func (r *schemeRouter) Do(req *http.Request) (*http.Response, error) {
+	if t, ok := r.handlers[req.URL.Scheme]; ok {
+		return t.Do(req)
+	}
+	return (&httpTransport{}).Do(req)
+}
+
+// RoundTrip adapts schemeRouter to http.RoundTripper so it can be used as
+// an *http.Client's Transport.
+func (r *schemeRouter) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.Do(req)
+}
+
+// router builds the scheme router used for the browser's next request,
+// registering the Gemini and Gopher handlers alongside the default
+// net/http one.
+func (bow *Browser) router() *schemeRouter {
+	return bow.routerFor(bow.transport)
+}
+
+// routerFor is like router, but uses rt for http/https instead of
+// bow.transport. It's used by client() to preserve a custom
+// *http.Client's own Transport (set via SetHTTPClient) while still routing
+// gemini:// and gopher:// requests to the browser's handlers.
+func (bow *Browser) routerFor(rt http.RoundTripper) *schemeRouter {
+	r := newSchemeRouter(rt)
+	r.register("gemini", &geminiTransport{bow: bow})
+	r.register("gopher", &gopherTransport{bow: bow})
+	return r
+}
@@ -0,0 +1,117 @@
+// Package errors provides the typed errors returned by the other surf packages.
+package errors
+
+import "fmt"
+
+// PageNotLoaded is returned when an operation requires a page to already be
+// loaded into the browser.
+type PageNotLoaded struct {
+	msg string
+}
+
+// NewPageNotLoaded creates and returns a *PageNotLoaded error.
+func NewPageNotLoaded(format string, a ...interface{}) *PageNotLoaded {
+	return &PageNotLoaded{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *PageNotLoaded) Error() string {
+	return e.msg
+}
+
+// ElementNotFound is returned when a page element can't be found using the
+// given expression.
+type ElementNotFound struct {
+	msg string
+}
+
+// NewElementNotFound creates and returns an *ElementNotFound error.
+func NewElementNotFound(format string, a ...interface{}) *ElementNotFound {
+	return &ElementNotFound{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *ElementNotFound) Error() string {
+	return e.msg
+}
+
+// LinkNotFound is returned when a link can't be found using the given
+// expression.
+type LinkNotFound struct {
+	msg string
+}
+
+// NewLinkNotFound creates and returns a *LinkNotFound error.
+func NewLinkNotFound(format string, a ...interface{}) *LinkNotFound {
+	return &LinkNotFound{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *LinkNotFound) Error() string {
+	return e.msg
+}
+
+// Location is returned when the browser is instructed not to follow a
+// redirect.
+type Location struct {
+	msg string
+}
+
+// NewLocation creates and returns a *Location error.
+func NewLocation(format string, a ...interface{}) *Location {
+	return &Location{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *Location) Error() string {
+	return e.msg
+}
+
+// BookmarkNotFound is returned when a bookmark can't be found under the
+// given name.
+type BookmarkNotFound struct {
+	msg string
+}
+
+// NewBookmarkNotFound creates and returns a *BookmarkNotFound error.
+func NewBookmarkNotFound(format string, a ...interface{}) *BookmarkNotFound {
+	return &BookmarkNotFound{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *BookmarkNotFound) Error() string {
+	return e.msg
+}
+
+// RobotsDenied is returned when a request is blocked by the target host's
+// robots.txt policy.
+type RobotsDenied struct {
+	msg string
+}
+
+// NewRobotsDenied creates and returns a *RobotsDenied error.
+func NewRobotsDenied(format string, a ...interface{}) *RobotsDenied {
+	return &RobotsDenied{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *RobotsDenied) Error() string {
+	return e.msg
+}
+
+// GeminiProtocol is returned when a gemini:// request fails because the
+// server violated the protocol, or because its certificate failed
+// trust-on-first-use verification.
+type GeminiProtocol struct {
+	msg string
+}
+
+// NewGeminiProtocol creates and returns a *GeminiProtocol error.
+func NewGeminiProtocol(format string, a ...interface{}) *GeminiProtocol {
+	return &GeminiProtocol{msg: fmt.Sprintf(format, a...)}
+}
+
+// Error satisfies the error interface.
+func (e *GeminiProtocol) Error() string {
+	return e.msg
+}
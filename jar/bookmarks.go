@@ -0,0 +1,39 @@
+package jar
+
+import "time"
+
+// Bookmark describes a single saved bookmark entry.
+type Bookmark struct {
+	// Name is the name the bookmark was saved under.
+	Name string `json:"name"`
+
+	// URL is the page the bookmark points at.
+	URL string `json:"url"`
+
+	// Tags are optional, user-supplied labels for the bookmark.
+	Tags []string `json:"tags,omitempty"`
+
+	// CreatedAt is when the bookmark was first saved.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BookmarksJar represents the behavior of a bookmarks storage facility.
+type BookmarksJar interface {
+	// Save saves a bookmark with the given name and URL, overwriting any
+	// bookmark already saved under that name. Any tags given are stored
+	// alongside the bookmark and surfaced by AllBookmarks.
+	Save(name, url string, tags ...string) error
+
+	// Remove deletes the bookmark with the given name.
+	Remove(name string) error
+
+	// Read returns the URL for the bookmark with the given name.
+	Read(name string) (string, error)
+
+	// All returns every saved bookmark as a map of name to URL.
+	All() map[string]string
+
+	// AllBookmarks returns every saved bookmark, tags and created-at
+	// timestamp included.
+	AllBookmarks() []*Bookmark
+}
@@ -0,0 +1,187 @@
+package jar
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/headzoo/surf/errors"
+)
+
+// changeEntry is a single line of the FileBookmarksJar change log, recording
+// when a bookmark was added or overwritten.
+type changeEntry struct {
+	Time time.Time `json:"time"`
+	Name string    `json:"name"`
+	URL  string    `json:"url"`
+}
+
+// FileBookmarksJar is a BookmarksJar that persists bookmarks to a JSON file
+// on disk. Writes are atomic: the new contents are written to a temporary
+// file in the same directory and renamed over the destination, so a crash
+// mid-save can't corrupt the store.
+type FileBookmarksJar struct {
+	mu        sync.RWMutex
+	path      string
+	logPath   string
+	bookmarks map[string]*Bookmark
+}
+
+// NewFileBookmarksJar creates a *FileBookmarksJar backed by the file at path,
+// loading any bookmarks already saved there. The file is created on the
+// first Save if it doesn't yet exist. The change log is disabled by
+// default; enable it with SetChangeLogPath.
+func NewFileBookmarksJar(path string) (*FileBookmarksJar, error) {
+	jar := &FileBookmarksJar{
+		path:      path,
+		bookmarks: make(map[string]*Bookmark),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return jar, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return jar, nil
+	}
+	if err := json.Unmarshal(data, &jar.bookmarks); err != nil {
+		return nil, err
+	}
+
+	return jar, nil
+}
+
+// SetChangeLogPath sets the file every Save appends a change-log entry to,
+// describing when a bookmark was added or overwritten. An empty path (the
+// default) disables the change log.
+func (j *FileBookmarksJar) SetChangeLogPath(path string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.logPath = path
+}
+
+// Save saves a bookmark with the given name and URL, overwriting any
+// bookmark already saved under that name. Any tags given are stored
+// alongside the bookmark and surfaced by AllBookmarks.
+func (j *FileBookmarksJar) Save(name, url string, tags ...string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	createdAt := time.Now()
+	if existing, ok := j.bookmarks[name]; ok && !existing.CreatedAt.IsZero() {
+		createdAt = existing.CreatedAt
+	}
+	j.bookmarks[name] = &Bookmark{
+		Name:      name,
+		URL:       url,
+		Tags:      tags,
+		CreatedAt: createdAt,
+	}
+	if err := j.writeLocked(); err != nil {
+		return err
+	}
+	return j.appendLogLocked(name, url)
+}
+
+// Remove deletes the bookmark with the given name.
+func (j *FileBookmarksJar) Remove(name string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delete(j.bookmarks, name)
+	return j.writeLocked()
+}
+
+// Read returns the URL for the bookmark with the given name.
+func (j *FileBookmarksJar) Read(name string) (string, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	b, ok := j.bookmarks[name]
+	if !ok {
+		return "", errors.NewBookmarkNotFound("No bookmark found with the name '%s'.", name)
+	}
+	return b.URL, nil
+}
+
+// All returns every saved bookmark as a map of name to URL.
+func (j *FileBookmarksJar) All() map[string]string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	all := make(map[string]string, len(j.bookmarks))
+	for name, b := range j.bookmarks {
+		all[name] = b.URL
+	}
+	return all
+}
+
+// AllBookmarks returns every saved bookmark, tags and created-at timestamp
+// included.
+func (j *FileBookmarksJar) AllBookmarks() []*Bookmark {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	all := make([]*Bookmark, 0, len(j.bookmarks))
+	for _, b := range j.bookmarks {
+		cp := *b
+		all = append(all, &cp)
+	}
+	return all
+}
+
+// writeLocked serializes the bookmarks to j.path, writing to a temporary
+// file and renaming it into place so a crash mid-write can't corrupt the
+// store. The caller must hold j.mu.
+func (j *FileBookmarksJar) writeLocked() error {
+	data, err := json.MarshalIndent(j.bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(j.path)
+	tmp, err := ioutil.TempFile(dir, ".bookmarks-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, j.path)
+}
+
+// appendLogLocked appends a change-log entry recording the save of name/url.
+// The caller must hold j.mu.
+func (j *FileBookmarksJar) appendLogLocked(name, url string) error {
+	if j.logPath == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(j.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entry, err := json.Marshal(changeEntry{Time: time.Now(), Name: name, URL: url})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(entry, '\n'))
+	return err
+}
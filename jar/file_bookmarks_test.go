@@ -0,0 +1,124 @@
+package jar
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileBookmarksJarSaveReadRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	j, err := NewFileBookmarksJar(path)
+	if err != nil {
+		t.Fatalf("NewFileBookmarksJar: %v", err)
+	}
+
+	if err := j.Save("golang", "https://golang.org", "lang", "docs"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	url, err := j.Read("golang")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if url != "https://golang.org" {
+		t.Errorf("Read returned %q, want %q", url, "https://golang.org")
+	}
+
+	all := j.AllBookmarks()
+	if len(all) != 1 {
+		t.Fatalf("AllBookmarks returned %d entries, want 1", len(all))
+	}
+	if got := all[0].Tags; len(got) != 2 || got[0] != "lang" || got[1] != "docs" {
+		t.Errorf("AllBookmarks tags = %v, want [lang docs]", got)
+	}
+	if all[0].CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+
+	if err := j.Remove("golang"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := j.Read("golang"); err == nil {
+		t.Error("expected Read to fail after Remove")
+	}
+}
+
+func TestFileBookmarksJarPersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+
+	j1, err := NewFileBookmarksJar(path)
+	if err != nil {
+		t.Fatalf("NewFileBookmarksJar: %v", err)
+	}
+	if err := j1.Save("example", "https://example.com"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	j2, err := NewFileBookmarksJar(path)
+	if err != nil {
+		t.Fatalf("NewFileBookmarksJar (reload): %v", err)
+	}
+	url, err := j2.Read("example")
+	if err != nil {
+		t.Fatalf("Read after reload: %v", err)
+	}
+	if url != "https://example.com" {
+		t.Errorf("Read after reload returned %q, want %q", url, "https://example.com")
+	}
+}
+
+func TestFileBookmarksJarChangeLog(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "changes.log")
+
+	j, err := NewFileBookmarksJar(filepath.Join(dir, "bookmarks.json"))
+	if err != nil {
+		t.Fatalf("NewFileBookmarksJar: %v", err)
+	}
+	j.SetChangeLogPath(logPath)
+
+	if err := j.Save("golang", "https://golang.org"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading change log: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("expected the change log to contain an entry")
+	}
+}
+
+// TestFileBookmarksJarConcurrentSaveAndRead exercises the sync.RWMutex
+// guarding bookmarks/the on-disk file under concurrent access; run with
+// -race to catch data races.
+func TestFileBookmarksJarConcurrentSaveAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	j, err := NewFileBookmarksJar(path)
+	if err != nil {
+		t.Fatalf("NewFileBookmarksJar: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := "bookmark"
+			if i%2 == 0 {
+				name = "other"
+			}
+			if err := j.Save(name, "https://example.com"); err != nil {
+				t.Errorf("Save: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = j.All()
+		}()
+	}
+	wg.Wait()
+}